@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var registerCmd = &cobra.Command{
+	Use:   "register",
+	Short: "Create a new user account",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return register()
+	},
+}
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in and persist the session for other commands",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return login()
+	},
+}
+
+func register() error {
+	name := scanInput("Enter your name: ")
+	email := scanInput("Enter your email: ")
+
+	password, err := readPassword("Enter your password: ")
+	if err != nil {
+		return fmt.Errorf("reading password: %w", err)
+	}
+
+	if len(password) < minPasswordLength {
+		return fmt.Errorf("password must be at least %d characters long", minPasswordLength)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("hashing password: %w", err)
+	}
+
+	user, err := store.CreateUser(User{
+		Name:     name,
+		Email:    email,
+		Password: string(hashedPassword),
+	})
+	if err != nil {
+		return fmt.Errorf("saving user: %w", err)
+	}
+
+	loggedInUser = user
+	if err := saveSession(user); err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+
+	fmt.Println("User registered successfully!")
+	return nil
+}
+
+// login re-prompts for credentials until they succeed, rather than
+// exiting on the first wrong attempt, so an interactive `tasky login`
+// behaves like a normal login prompt instead of a one-shot check.
+func login() error {
+	for {
+		email := scanInput("Enter your email: ")
+		password, err := readPassword("Enter your password: ")
+		if err != nil {
+			logger.Error("reading password", "err", err)
+			continue
+		}
+
+		user, ok, err := store.FindUserByEmail(email)
+		if err != nil {
+			return fmt.Errorf("looking up user: %w", err)
+		}
+
+		if ok && bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) == nil {
+			loggedInUser = user
+			if err := saveSession(user); err != nil {
+				return fmt.Errorf("saving session: %w", err)
+			}
+			fmt.Printf("Logged in as: %s\n", email)
+			return nil
+		}
+
+		fmt.Println("Wrong credentials! Please try again.")
+	}
+}
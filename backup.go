@@ -0,0 +1,369 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// backupSchemaVersion identifies the shape of the Storage struct written
+// into a backup archive's data.json. restore refuses an archive whose
+// manifest names a different version, rather than risk misreading it.
+const backupSchemaVersion = 1
+
+// backupManifest travels alongside data.json inside a backup archive.
+// restore checks it before touching the live store: a schema mismatch or
+// a bad checksum means the archive is from an incompatible tasky version
+// or corrupt, and should be rejected outright rather than half-applied.
+type backupManifest struct {
+	SchemaVersion  int       `json:"schema_version"`
+	CreatedAt      time.Time `json:"created_at"`
+	ChecksumSHA256 string    `json:"checksum_sha256"`
+	UserID         *int      `json:"user_id,omitempty"`
+	Users          int       `json:"users"`
+	Tasks          int       `json:"tasks"`
+	Categories     int       `json:"categories"`
+}
+
+var (
+	backupDir    string
+	backupUserID int
+
+	restoreDir    string
+	restoreUserID int
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Write a timestamped tar.gz snapshot of the store",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		archivePath, err := runBackup(backupDir, backupUserID)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Backup written to", archivePath)
+		return nil
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore the store from a backup written by `tasky backup`",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRestore(restoreDir, restoreUserID)
+	},
+}
+
+func init() {
+	backupCmd.Flags().StringVar(&backupDir, "dir", "", "directory to write the backup archive into (required)")
+	backupCmd.Flags().IntVar(&backupUserID, "user", 0, "only back up this user's tasks and categories")
+	backupCmd.MarkFlagRequired("dir")
+
+	restoreCmd.Flags().StringVar(&restoreDir, "dir", "", "backup archive, or a directory to restore the newest archive from (required)")
+	restoreCmd.Flags().IntVar(&restoreUserID, "user", 0, "only restore this user's tasks and categories, leaving everyone else untouched")
+	restoreCmd.MarkFlagRequired("dir")
+}
+
+// runBackup snapshots the active store (or just userID's slice of it),
+// writes data.json and a manifest.json describing it into a tar.gz named
+// after the current time, and returns the archive's path.
+func runBackup(dir string, userID int) (string, error) {
+	data, err := store.Snapshot()
+	if err != nil {
+		return "", fmt.Errorf("reading store: %w", err)
+	}
+
+	if userID != 0 {
+		if data, err = filterStorageForUser(data, userID); err != nil {
+			return "", err
+		}
+	}
+
+	payload, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encoding data.json: %w", err)
+	}
+	checksum := sha256.Sum256(payload)
+
+	manifest := backupManifest{
+		SchemaVersion:  backupSchemaVersion,
+		CreatedAt:      time.Now(),
+		ChecksumSHA256: hex.EncodeToString(checksum[:]),
+		Users:          len(data.Users),
+		Tasks:          len(data.Tasks),
+		Categories:     len(data.Categories),
+	}
+	if userID != 0 {
+		manifest.UserID = &userID
+	}
+
+	manifestPayload, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encoding manifest.json: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", dir, err)
+	}
+
+	archivePath := filepath.Join(dir, fmt.Sprintf("tasky-backup-%s.tar.gz", manifest.CreatedAt.Format("20060102-150405")))
+	if err := writeBackupArchive(archivePath, manifestPayload, payload); err != nil {
+		return "", err
+	}
+
+	return archivePath, nil
+}
+
+// runRestore validates a backup archive (schema version, then checksum)
+// before applying it. With userID set, only that user's tasks and
+// categories are replaced; every other user's data, and the user records
+// themselves, are left exactly as they were in the live store.
+func runRestore(dirOrArchive string, userID int) error {
+	archivePath, err := resolveBackupArchive(dirOrArchive)
+	if err != nil {
+		return err
+	}
+
+	manifestPayload, payload, err := readBackupArchive(archivePath)
+	if err != nil {
+		return err
+	}
+
+	var manifest backupManifest
+	if err := json.Unmarshal(manifestPayload, &manifest); err != nil {
+		return fmt.Errorf("parsing manifest in %s: %w", archivePath, err)
+	}
+	if manifest.SchemaVersion != backupSchemaVersion {
+		return fmt.Errorf("%s has schema version %d, but this tasky only restores version %d",
+			archivePath, manifest.SchemaVersion, backupSchemaVersion)
+	}
+
+	// A --user backup's data.json only contains that one user, so
+	// restoring it as a full-store payload (the userID == 0 branch
+	// below) would wipe out every other user in the live store. Require
+	// --user on the restore side too, and require it to name the same
+	// user the backup was scoped to.
+	if manifest.UserID != nil && userID != *manifest.UserID {
+		return fmt.Errorf("%s is scoped to user #%d; restore it with --user %d (or re-run backup without --user for a full-store archive)",
+			archivePath, *manifest.UserID, *manifest.UserID)
+	}
+	if manifest.UserID == nil && userID != 0 {
+		return fmt.Errorf("%s is a full-store backup, not scoped to user #%d; restore without --user", archivePath, userID)
+	}
+
+	checksum := sha256.Sum256(payload)
+	if hex.EncodeToString(checksum[:]) != manifest.ChecksumSHA256 {
+		return fmt.Errorf("%s failed its checksum check; the archive may be corrupt", archivePath)
+	}
+
+	var backup Storage
+	if err := json.Unmarshal(payload, &backup); err != nil {
+		return fmt.Errorf("parsing data.json in %s: %w", archivePath, err)
+	}
+
+	data := backup
+	if userID != 0 {
+		if backup, err = filterStorageForUser(backup, userID); err != nil {
+			return fmt.Errorf("restoring from %s: %w", archivePath, err)
+		}
+		if data, err = mergeUserIntoStore(userID, backup); err != nil {
+			return err
+		}
+	}
+
+	// store.Restore does its own atomic swap of the live data: a
+	// transaction for sqliteStore, a write-to-temp-then-os.Rename for
+	// encryptedStore, a locked rewrite for jsonStore. Same path `tasky
+	// migrate` uses to move data between backends.
+	if err := store.Restore(data); err != nil {
+		return fmt.Errorf("writing restored data: %w", err)
+	}
+
+	fmt.Printf("Restored %d user(s), %d task(s), %d categories from %s\n",
+		len(data.Users), len(data.Tasks), len(data.Categories), archivePath)
+	return nil
+}
+
+// filterStorageForUser narrows a Storage to a single user's record, tasks
+// and categories. It errors if userID isn't present, so a typo in --user
+// fails loudly instead of silently backing up (or restoring) nothing.
+func filterStorageForUser(data Storage, userID int) (Storage, error) {
+	var (
+		user  User
+		found bool
+	)
+	for _, u := range data.Users {
+		if u.ID == userID {
+			user, found = u, true
+			break
+		}
+	}
+	if !found {
+		return Storage{}, fmt.Errorf("no user #%d in this store", userID)
+	}
+
+	filtered := Storage{Users: []User{user}}
+	for _, t := range data.Tasks {
+		if t.UserID == userID {
+			filtered.Tasks = append(filtered.Tasks, t)
+		}
+	}
+	for _, c := range data.Categories {
+		if c.UserID == userID {
+			filtered.Categories = append(filtered.Categories, c)
+		}
+	}
+	return filtered, nil
+}
+
+// mergeUserIntoStore takes the live store's current snapshot and swaps in
+// backup's tasks and categories for userID, leaving every other user's
+// data (and the Users table itself) untouched.
+func mergeUserIntoStore(userID int, backup Storage) (Storage, error) {
+	current, err := store.Snapshot()
+	if err != nil {
+		return Storage{}, fmt.Errorf("reading store: %w", err)
+	}
+
+	tasks := []Task{}
+	for _, t := range current.Tasks {
+		if t.UserID != userID {
+			tasks = append(tasks, t)
+		}
+	}
+	current.Tasks = append(tasks, backup.Tasks...)
+
+	categories := []Category{}
+	for _, c := range current.Categories {
+		if c.UserID != userID {
+			categories = append(categories, c)
+		}
+	}
+	current.Categories = append(categories, backup.Categories...)
+
+	return current, nil
+}
+
+// writeBackupArchive tars manifest.json and data.json into a gzip
+// archive, writing to a temp file first so a reader never sees a
+// partially-written backup at archivePath.
+func writeBackupArchive(archivePath string, manifestPayload, dataPayload []byte) error {
+	tmp := archivePath + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", tmp, err)
+	}
+	defer os.Remove(tmp)
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	for _, entry := range []struct {
+		name string
+		body []byte
+	}{
+		{"manifest.json", manifestPayload},
+		{"data.json", dataPayload},
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: entry.name, Mode: 0644, Size: int64(len(entry.body))}); err != nil {
+			file.Close()
+			return fmt.Errorf("writing %s header: %w", entry.name, err)
+		}
+		if _, err := tw.Write(entry.body); err != nil {
+			file.Close()
+			return fmt.Errorf("writing %s: %w", entry.name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		file.Close()
+		return fmt.Errorf("closing tar writer for %s: %w", tmp, err)
+	}
+	if err := gz.Close(); err != nil {
+		file.Close()
+		return fmt.Errorf("closing gzip writer for %s: %w", tmp, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", tmp, err)
+	}
+
+	return os.Rename(tmp, archivePath)
+}
+
+// readBackupArchive extracts manifest.json and data.json from a tar.gz
+// written by writeBackupArchive.
+func readBackupArchive(archivePath string) (manifestPayload, dataPayload []byte, err error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening %s: %w", archivePath, err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading %s: %w", archivePath, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading %s: %w", archivePath, err)
+		}
+
+		body, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading %s from %s: %w", hdr.Name, archivePath, err)
+		}
+
+		switch hdr.Name {
+		case "manifest.json":
+			manifestPayload = body
+		case "data.json":
+			dataPayload = body
+		}
+	}
+
+	if manifestPayload == nil || dataPayload == nil {
+		return nil, nil, fmt.Errorf("%s is missing manifest.json or data.json", archivePath)
+	}
+	return manifestPayload, dataPayload, nil
+}
+
+// resolveBackupArchive accepts either a direct path to a tar.gz, or a
+// directory to back up into/restore from, and returns the archive path
+// to actually read: --dir's newest tasky-backup-*.tar.gz when it's a
+// directory, or itself otherwise.
+func resolveBackupArchive(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("stat %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(path, "tasky-backup-*.tar.gz"))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no tasky-backup-*.tar.gz archives in %s", path)
+	}
+	sort.Strings(matches)
+	return matches[len(matches)-1], nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// withBackupTestStore sets up a json store with two users, each with one
+// task, and points the package-level store at it for the test.
+func withBackupTestStore(t *testing.T) (alice, bob User) {
+	t.Helper()
+
+	prevStore := store
+	t.Cleanup(func() {
+		if store != nil {
+			store.Close()
+		}
+		store = prevStore
+	})
+
+	s, err := openStore("json", filepath.Join(t.TempDir(), "data.json"), "")
+	if err != nil {
+		t.Fatalf("openStore: %v", err)
+	}
+	store = s
+
+	alice, err = store.CreateUser(User{Name: "alice", Email: "alice@x.com", Password: "hash1"})
+	if err != nil {
+		t.Fatalf("CreateUser(alice): %v", err)
+	}
+	bob, err = store.CreateUser(User{Name: "bob", Email: "bob@x.com", Password: "hash2"})
+	if err != nil {
+		t.Fatalf("CreateUser(bob): %v", err)
+	}
+
+	if _, err := store.CreateTask(Task{Name: "alice's task", UserID: alice.ID, Status: TaskStatusPending}); err != nil {
+		t.Fatalf("CreateTask(alice): %v", err)
+	}
+	if _, err := store.CreateTask(Task{Name: "bob's task", UserID: bob.ID, Status: TaskStatusPending}); err != nil {
+		t.Fatalf("CreateTask(bob): %v", err)
+	}
+
+	return alice, bob
+}
+
+func TestBackupRestoreFullStore(t *testing.T) {
+	withBackupTestStore(t)
+	dir := t.TempDir()
+
+	if _, err := runBackup(dir, 0); err != nil {
+		t.Fatalf("runBackup: %v", err)
+	}
+	if err := runRestore(dir, 0); err != nil {
+		t.Fatalf("runRestore: %v", err)
+	}
+
+	snapshot, err := store.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if len(snapshot.Users) != 2 || len(snapshot.Tasks) != 2 {
+		t.Fatalf("expected both users and tasks to survive a full-store roundtrip, got %+v", snapshot)
+	}
+}
+
+// TestBackupRestoreRejectsUnscopedRestoreOfScopedBackup is the regression
+// test for the data-loss bug where `tasky restore --dir X` (no --user)
+// against a --user-scoped backup silently wiped every other user.
+func TestBackupRestoreRejectsUnscopedRestoreOfScopedBackup(t *testing.T) {
+	alice, _ := withBackupTestStore(t)
+	dir := t.TempDir()
+
+	if _, err := runBackup(dir, alice.ID); err != nil {
+		t.Fatalf("runBackup(--user alice): %v", err)
+	}
+
+	if err := runRestore(dir, 0); err == nil {
+		t.Fatal("expected runRestore without --user to refuse a --user-scoped backup")
+	}
+
+	snapshot, err := store.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if len(snapshot.Users) != 2 || len(snapshot.Tasks) != 2 {
+		t.Fatalf("refused restore must leave the store untouched, got %+v", snapshot)
+	}
+}
+
+// TestBackupRestoreScopedToMatchingUser checks that restoring with --user
+// matching the backup's scope merges in that user's data and leaves
+// everyone else alone.
+func TestBackupRestoreScopedToMatchingUser(t *testing.T) {
+	alice, bob := withBackupTestStore(t)
+	dir := t.TempDir()
+
+	if _, err := runBackup(dir, alice.ID); err != nil {
+		t.Fatalf("runBackup(--user alice): %v", err)
+	}
+
+	if err := store.DeleteTask(alice.ID, 1); err != nil {
+		t.Fatalf("DeleteTask(alice): %v", err)
+	}
+
+	if err := runRestore(dir, alice.ID); err != nil {
+		t.Fatalf("runRestore(--user alice): %v", err)
+	}
+
+	snapshot, err := store.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if len(snapshot.Users) != 2 {
+		t.Fatalf("expected bob to survive a --user alice restore, got %+v", snapshot.Users)
+	}
+
+	aliceTasks, err := store.ListTasks(alice.ID)
+	if err != nil || len(aliceTasks) != 1 {
+		t.Fatalf("expected alice's task to be restored, got %+v, %v", aliceTasks, err)
+	}
+	bobTasks, err := store.ListTasks(bob.ID)
+	if err != nil || len(bobTasks) != 1 {
+		t.Fatalf("expected bob's task to be untouched, got %+v, %v", bobTasks, err)
+	}
+}
+
+// TestBackupRestoreRejectsMismatchedUser checks --user N against a backup
+// scoped to a different user is refused rather than silently merged.
+func TestBackupRestoreRejectsMismatchedUser(t *testing.T) {
+	alice, bob := withBackupTestStore(t)
+	dir := t.TempDir()
+
+	if _, err := runBackup(dir, alice.ID); err != nil {
+		t.Fatalf("runBackup(--user alice): %v", err)
+	}
+
+	if err := runRestore(dir, bob.ID); err == nil {
+		t.Fatal("expected runRestore --user bob to refuse a backup scoped to alice")
+	}
+}
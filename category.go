@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var categoryCmd = &cobra.Command{
+	Use:   "category",
+	Short: "Manage task categories",
+	// A child's PersistentPreRunE replaces the parent's rather than
+	// chaining to it, so rootCmd's store/logger setup has to be called
+	// explicitly here before requireLogin() can use either.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := rootCmd.PersistentPreRunE(cmd, args); err != nil {
+			return err
+		}
+		return requireLogin()
+	},
+}
+
+var categoryCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a category",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+
+		category, err := addCategory(name)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Category created: #%d %s\n", category.ID, category.Name)
+		return nil
+	},
+}
+
+func init() {
+	categoryCreateCmd.Flags().String("name", "", "category name (required)")
+	categoryCreateCmd.MarkFlagRequired("name")
+
+	categoryCmd.AddCommand(categoryCreateCmd)
+}
+
+func addCategory(name string) (Category, error) {
+	return store.CreateCategory(Category{
+		Name:   name,
+		UserID: loggedInUser.ID,
+	})
+}
+
+// createCategory is the interactive counterpart used by `tasky shell`.
+func createCategory() {
+	name := scanInput("Enter your category name: ")
+
+	if _, err := addCategory(name); err != nil {
+		logger.Error("creating category", "name", name, "err", err)
+		return
+	}
+
+	fmt.Printf("Category created: %s\n", name)
+}
+
+func getUserCategories() []Category {
+	categories, err := store.ListCategories(loggedInUser.ID)
+	if err != nil {
+		logger.Error("listing categories", "user_id", loggedInUser.ID, "err", err)
+		return nil
+	}
+	return categories
+}
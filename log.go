@@ -0,0 +1,38 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+)
+
+// logger is the process-wide diagnostic logger. User-facing prompts and
+// results (the "Task created: #3 ..." lines) stay on stdout via fmt;
+// logger is for everything else: errors on paths that don't return one,
+// and anything worth grepping out of a `tasky` run under systemd. It
+// defaults to info/text so commands still log sensibly before root.go's
+// PersistentPreRunE has parsed --log-level/--log-format.
+var logger = newLogger("info", "text")
+
+// newLogger builds a leveled, structured logger writing to stderr.
+// format is "text" (human-readable key=value pairs, the default) or
+// "json" (one record per line, for journald or a log aggregator); level
+// is any slog level name (debug, info, warn, error), case-insensitive.
+// An unrecognized level falls back to info rather than failing startup.
+func newLogger(level, format string) *slog.Logger {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		lvl = slog.LevelInfo
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+
+	var handler slog.Handler
+	switch format {
+	case "json":
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	default:
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	}
+
+	return slog.New(handler)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateFromKind       string
+	migrateFromPath       string
+	migrateFromPassphrase string
+	migrateToKind         string
+	migrateToPath         string
+	migrateToPassphrase   string
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Copy all data from one store backend to another",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMigrate(migrateFromKind, migrateFromPath, migrateFromPassphrase, migrateToKind, migrateToPath, migrateToPassphrase)
+	},
+}
+
+func init() {
+	migrateCmd.Flags().StringVar(&migrateFromKind, "from", "json", "source backend: json, sqlite or encrypted")
+	migrateCmd.Flags().StringVar(&migrateFromPath, "from-path", "", "path to the source store's data file")
+	migrateCmd.Flags().StringVar(&migrateFromPassphrase, "from-passphrase", "", "passphrase for the source store, if it's encrypted")
+	migrateCmd.Flags().StringVar(&migrateToKind, "to", "", "destination backend: json, sqlite or encrypted")
+	migrateCmd.Flags().StringVar(&migrateToPath, "to-path", "", "path to the destination store's data file")
+	migrateCmd.Flags().StringVar(&migrateToPassphrase, "to-passphrase", "", "passphrase for the destination store, if it's encrypted")
+	migrateCmd.MarkFlagRequired("to")
+}
+
+// migrateInteractive is the `tasky shell` counterpart of `tasky migrate`.
+func migrateInteractive() {
+	fromKind := scanInput("Migrate from backend (json/sqlite/encrypted): ")
+	fromPath := scanInput("Migrate from path: ")
+	toKind := scanInput("Migrate to backend (json/sqlite/encrypted): ")
+	toPath := scanInput("Migrate to path: ")
+
+	if err := runMigrate(fromKind, fromPath, "", toKind, toPath, ""); err != nil {
+		logger.Error("migrating store", "from", fromKind, "to", toKind, "err", err)
+	}
+}
+
+// runMigrate copies every record from the store at fromKind/fromPath into
+// a fresh store at toKind/toPath. It's a straight Snapshot + Restore, so
+// the destination ends up with the source's IDs and timestamps; the sqlite
+// backend stores timestamps to the second, dropping any sub-second part.
+func runMigrate(fromKind, fromPath, fromPassphrase, toKind, toPath, toPassphrase string) error {
+	from, err := openStore(fromKind, fromPath, fromPassphrase)
+	if err != nil {
+		return fmt.Errorf("opening source store: %w", err)
+	}
+	defer from.Close()
+
+	to, err := openStore(toKind, toPath, toPassphrase)
+	if err != nil {
+		return fmt.Errorf("opening destination store: %w", err)
+	}
+	defer to.Close()
+
+	data, err := from.Snapshot()
+	if err != nil {
+		return fmt.Errorf("reading source store: %w", err)
+	}
+
+	if err := to.Restore(data); err != nil {
+		return fmt.Errorf("writing destination store: %w", err)
+	}
+
+	fmt.Printf("Migrated %d user(s), %d task(s), %d categories from %s to %s\n",
+		len(data.Users), len(data.Tasks), len(data.Categories), fromKind, toKind)
+	return nil
+}
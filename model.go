@@ -0,0 +1,56 @@
+package main
+
+import "time"
+
+type User struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type Task struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Date        time.Time  `json:"date"`
+	Status      TaskStatus `json:"status"`
+	UserID      int        `json:"user_id"`
+	CategoryID  int        `json:"category_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// validTaskStatus reports whether s is one of the three TaskStatus
+// constants, used to validate `task set-status` input.
+func validTaskStatus(s TaskStatus) bool {
+	switch s {
+	case TaskStatusPending, TaskStatusInProgress, TaskStatusCompleted:
+		return true
+	default:
+		return false
+	}
+}
+
+type Category struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	UserID int    `json:"user_id"`
+}
+
+type TaskStatus string
+
+const (
+	TaskStatusPending    TaskStatus = "pending"
+	TaskStatusInProgress TaskStatus = "inProgress"
+	TaskStatusCompleted  TaskStatus = "completed"
+)
+
+// Storage is the full, backend-agnostic dump of application data. It is
+// used as the wire format for Snapshot/Restore, backups and migrations
+// between Store implementations.
+type Storage struct {
+	Users      []User     `json:"users"`
+	Tasks      []Task     `json:"tasks"`
+	Categories []Category `json:"categories"`
+}
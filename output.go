@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// renderTasks prints tasks to stdout in the requested format. table is
+// the human-friendly default; json and csv exist so `task list` output
+// can be piped into jq, a spreadsheet, or another script.
+func renderTasks(tasks []Task, format string) error {
+	switch format {
+	case "", "table":
+		for _, task := range tasks {
+			fmt.Printf("ID: %d, Name: %s, Date: %s, Status: %s\n",
+				task.ID, task.Name, task.Date.Format("2006-01-02"), task.Status)
+		}
+		return nil
+	case "json":
+		if tasks == nil {
+			tasks = []Task{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(tasks)
+	case "csv":
+		writer := csv.NewWriter(os.Stdout)
+		defer writer.Flush()
+		if err := writer.Write([]string{"id", "name", "date", "status", "category_id"}); err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			row := []string{
+				fmt.Sprint(task.ID),
+				task.Name,
+				task.Date.Format("2006-01-02"),
+				string(task.Status),
+				fmt.Sprint(task.CategoryID),
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q (want table, json or csv)", format)
+	}
+}
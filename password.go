@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+
+	"golang.org/x/term"
+)
+
+// minPasswordLength is enforced before a password is hashed in register().
+const minPasswordLength = 8
+
+// readPassword prompts for a password without echoing it to the terminal.
+// When stdin is a TTY it uses term.ReadPassword so the OS handles echo
+// suppression; otherwise (piped input, redirected files) there's no local
+// echo to suppress in the first place, so it falls back to readMaskedLine,
+// which prints its own '*' per byte read off the shared stdinReader.
+func readPassword(prompt string) (string, error) {
+	fmt.Print(prompt)
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return readMaskedLine()
+	}
+
+	// Restore terminal state on Ctrl-C instead of leaving the tty with echo off.
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	oldState, err := term.GetState(fd)
+	if err != nil {
+		return "", fmt.Errorf("reading terminal state: %w", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sigCh:
+			term.Restore(fd, oldState)
+			fmt.Println()
+			os.Exit(1)
+		case <-done:
+		}
+	}()
+
+	password, err := term.ReadPassword(fd)
+	close(done)
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("reading password: %w", err)
+	}
+
+	return string(password), nil
+}
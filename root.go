@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	storeKindFlag       string
+	storePathFlag       string
+	storePassphraseFlag string
+
+	logLevelFlag  string
+	logFormatFlag string
+)
+
+// rootCmd is the tasky entrypoint. Every subcommand shares the same
+// --store/--store-path flags and the same opened Store, so a command
+// can be scripted end to end (`tasky task create ... && tasky task list
+// --output json`) instead of going through the old scanner REPL.
+var rootCmd = &cobra.Command{
+	Use:           "tasky",
+	Short:         "A small multi-user task tracker",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		logger = newLogger(logLevelFlag, logFormatFlag)
+
+		var err error
+		store, err = openStore(storeKindFlag, storePathFlag, storePassphraseFlag)
+		return err
+	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		if store == nil {
+			return nil
+		}
+		return store.Close()
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&storeKindFlag, "store", os.Getenv("TASKY_STORE"),
+		"storage backend: json, sqlite or encrypted")
+	rootCmd.PersistentFlags().StringVar(&storePathFlag, "store-path", os.Getenv("TASKY_STORE_PATH"),
+		"path to the store's data file")
+	rootCmd.PersistentFlags().StringVar(&storePassphraseFlag, "store-passphrase", os.Getenv("TASKY_STORE_PASSPHRASE"),
+		"passphrase for the encrypted store backend, shared by every user of that store")
+
+	rootCmd.PersistentFlags().StringVar(&logLevelFlag, "log-level", envOr("TASKY_LOG_LEVEL", "info"),
+		"diagnostic log level: debug, info, warn or error")
+	rootCmd.PersistentFlags().StringVar(&logFormatFlag, "log-format", envOr("TASKY_LOG_FORMAT", "text"),
+		"diagnostic log format: text or json")
+
+	rootCmd.AddCommand(registerCmd, loginCmd, taskCmd, categoryCmd, shellCmd, migrateCmd, backupCmd, restoreCmd)
+}
+
+// envOr returns os.Getenv(key), falling back to def when the variable is unset or empty.
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// Execute runs the root command; main() maps a returned error to a
+// non-zero exit code instead of printing and continuing.
+func Execute() error {
+	return rootCmd.Execute()
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const sessionFileName = ".tasky-session.json"
+
+// session is the thin, on-disk record of who last logged in. Unlike
+// loggedInUser, which only lives for the duration of one process, this
+// is what lets separate `tasky task ...` invocations act as the same
+// user without re-authenticating every time.
+type session struct {
+	UserID int    `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+func sessionPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("locating home directory: %w", err)
+	}
+	return filepath.Join(home, sessionFileName), nil
+}
+
+func saveSession(user User) error {
+	path, err := sessionPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(session{UserID: user.ID, Email: user.Email})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+func clearSession() error {
+	path, err := sessionPath()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func loadSession() (session, bool, error) {
+	path, err := sessionPath()
+	if err != nil {
+		return session{}, false, err
+	}
+
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return session{}, false, nil
+	}
+	if err != nil {
+		return session{}, false, err
+	}
+
+	var s session
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return session{}, false, fmt.Errorf("parsing session file: %w", err)
+	}
+	return s, true, nil
+}
+
+// requireLogin loads the persisted session and resolves it against the
+// active store, populating loggedInUser for the current command. Every
+// task/category command runs this as a PersistentPreRunE.
+func requireLogin() error {
+	s, ok, err := loadSession()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("not logged in; run `tasky login` or `tasky register` first")
+	}
+
+	users, err := store.ListUsers()
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		if u.ID == s.UserID {
+			loggedInUser = u
+			return nil
+		}
+	}
+
+	return fmt.Errorf("session user no longer exists; run `tasky login` again")
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// shellCmd preserves the original scanner-driven REPL for interactive
+// use; every other command is now scriptable on its own, but `tasky
+// shell` is kept as an opt-in interactive mode.
+var shellCmd = &cobra.Command{
+	Use:   "shell",
+	Short: "Start an interactive REPL (the original tasky experience)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runShell()
+		return nil
+	},
+}
+
+type Commands map[string]func()
+
+func runShell() {
+	fmt.Println("Hello todo app")
+
+	fmt.Println("Please enter a command:")
+	for {
+		input, err := readLine()
+		if err != nil {
+			return
+		}
+
+		if input == "exit" {
+			fmt.Println("Goodbye!")
+			return
+		}
+
+		runShellCommand(input)
+
+		fmt.Println("Please enter another command:")
+	}
+}
+
+func runShellCommand(command string) {
+	if command != "register" && loggedInUser == (User{}) {
+		if err := login(); err != nil {
+			logger.Error("logging in", "err", err)
+		}
+		return
+	}
+
+	commands := Commands{
+		"create-task":     createTask,
+		"create-category": createCategory,
+		"register":        func() { handleShellError(register()) },
+		"login":           func() { handleShellError(login()) },
+		"list-tasks":      listTasks,
+		"migrate":         migrateInteractive,
+	}
+
+	call, ok := commands[command]
+	if !ok {
+		logger.Warn("unknown shell command", "command", command)
+		return
+	}
+
+	call()
+}
+
+func handleShellError(err error) {
+	if err != nil {
+		logger.Error(err.Error())
+	}
+}
+
+func scanInput(prompt string) string {
+	fmt.Print(prompt)
+	line, _ := readLine()
+	return line
+}
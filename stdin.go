@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// stdinReader is the single buffered reader over os.Stdin shared by every
+// prompt in the process: scanInput, the `tasky shell` command loop, and
+// readPassword's non-TTY fallback. A fresh bufio.Reader/Scanner per
+// prompt would read ahead into its own buffer and silently drop anything
+// past the first line, so piping several answers through one invocation
+// (`printf 'alice\nalice@x.com\nalicepass1\n' | tasky register`) would
+// lose every answer after the first.
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// readLine reads one line from stdin, trimming the trailing newline (and
+// a preceding \r, for CRLF input). A final line with no trailing newline
+// is still returned, with the triggering error (usually io.EOF) only
+// surfaced once there's nothing left to read.
+func readLine() (string, error) {
+	line, err := stdinReader.ReadString('\n')
+	line = strings.TrimRight(line, "\r\n")
+	if err != nil && line == "" {
+		return "", err
+	}
+	return line, nil
+}
+
+// readMaskedLine is readPassword's non-TTY fallback: term.ReadPassword
+// needs a real terminal to switch off local echo, so when stdin isn't
+// one (piped input, redirected files) this reads byte by byte off the
+// same shared stdinReader instead, printing '*' for each byte so the
+// password is never echoed in the clear, and stopping at the newline.
+func readMaskedLine() (string, error) {
+	var sb strings.Builder
+	for {
+		b, err := stdinReader.ReadByte()
+		if err != nil {
+			if sb.Len() > 0 {
+				break
+			}
+			return "", err
+		}
+		if b == '\n' {
+			break
+		}
+		if b == '\r' {
+			continue
+		}
+		sb.WriteByte(b)
+		fmt.Print("*")
+	}
+	fmt.Println()
+	return sb.String(), nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned by Store lookups that find no matching record.
+var ErrNotFound = errors.New("not found")
+
+// Store is the persistence boundary for the app: every command goes
+// through it instead of touching a data file directly, so the backend
+// (plain JSON, SQLite, an encrypted file, ...) can be swapped with the
+// --store flag without touching command code.
+type Store interface {
+	ListUsers() ([]User, error)
+	CreateUser(user User) (User, error)
+	FindUserByEmail(email string) (User, bool, error)
+
+	ListTasks(userID int) ([]Task, error)
+	CreateTask(task Task) (Task, error)
+	UpdateTask(task Task) error
+	DeleteTask(userID, taskID int) error
+
+	ListCategories(userID int) ([]Category, error)
+	CreateCategory(category Category) (Category, error)
+
+	// Snapshot and Restore move the entire store to and from the common
+	// Storage format, used by backup/restore and by `tasky migrate`.
+	Snapshot() (Storage, error)
+	Restore(data Storage) error
+
+	Close() error
+}
+
+// openStore constructs the Store backend named by kind. kind is typically
+// sourced from the --store flag or the TASKY_STORE environment variable.
+// passphrase is only used by the encrypted backend (from --store-passphrase
+// or TASKY_STORE_PASSPHRASE); every other backend ignores it.
+func openStore(kind, path, passphrase string) (Store, error) {
+	switch kind {
+	case "", "json":
+		return newJSONStore(path)
+	case "sqlite":
+		return newSQLiteStore(path)
+	case "encrypted":
+		store, err := newEncryptedStore(path)
+		if err != nil {
+			return nil, err
+		}
+		if passphrase != "" {
+			store.(*encryptedStore).SetPassphrase(passphrase)
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown store backend %q (want json, sqlite or encrypted)", kind)
+	}
+}
+
+func nextID[T any](items []T, idOf func(T) int) int {
+	max := 0
+	for _, item := range items {
+		if id := idOf(item); id > max {
+			max = id
+		}
+	}
+	return max + 1
+}
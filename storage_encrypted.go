@@ -0,0 +1,191 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	encryptedSaltSize = 16
+	scryptN           = 1 << 15
+	scryptR           = 8
+	scryptP           = 1
+	scryptKeyLen      = 32
+)
+
+// encryptedStore wraps the same JSON payload as jsonStore but encrypts it
+// at rest with AES-GCM, keyed via scrypt off a single passphrase shared
+// by every user of the store (--store-passphrase / TASKY_STORE_PASSPHRASE)
+// rather than any one user's own login password: the store holds every
+// user's data, and a per-login-password key would make the file
+// unreadable the moment a second user logged in with a different
+// password. The passphrase is supplied separately through SetPassphrase,
+// which openStore calls right after construction; until then the store
+// can't be read or written. Every CRUD method comes from the embedded
+// fileCRUD.
+//
+// lockedUpdate guards the read-decrypt-modify-encrypt-write cycle with
+// both an in-process mutex and an flock, same as jsonStore. The flock is
+// taken on a dedicated path+".lock" file rather than path itself: the
+// write side replaces path with os.Rename for atomicity, so a lock held
+// on path's original file descriptor would stop protecting anything the
+// instant the rename swaps in a new inode. A lock file that's never
+// renamed doesn't have that problem.
+type encryptedStore struct {
+	fileCRUD
+	path       string
+	mu         sync.Mutex
+	passphrase string
+}
+
+func newEncryptedStore(path string) (Store, error) {
+	if path == "" {
+		path = "data.json.enc"
+	}
+	s := &encryptedStore{path: path}
+	s.fileCRUD = fileCRUD{withLock: s.lockedUpdate}
+	return s, nil
+}
+
+// SetPassphrase unlocks the store. It is not part of the Store interface
+// because only the encrypted backend needs it; openStore type-asserts
+// for it right after construction.
+func (s *encryptedStore) SetPassphrase(passphrase string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.passphrase = passphrase
+}
+
+func (s *encryptedStore) lockedUpdate(fn func(*Storage) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.passphrase == "" {
+		return fmt.Errorf("encrypted store is locked: pass --store-passphrase or set TASKY_STORE_PASSPHRASE")
+	}
+
+	lockFile, err := os.OpenFile(s.path+".lock", os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("opening %s.lock: %w", s.path, err)
+	}
+	defer lockFile.Close()
+
+	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("locking %s.lock: %w", s.path, err)
+	}
+	defer syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN)
+
+	data := Storage{Users: []User{}, Tasks: []Task{}, Categories: []Category{}}
+
+	raw, err := os.ReadFile(s.path)
+	switch {
+	case os.IsNotExist(err):
+		// start empty
+	case err != nil:
+		return fmt.Errorf("reading %s: %w", s.path, err)
+	default:
+		plaintext, err := decrypt(raw, s.passphrase)
+		if err != nil {
+			return fmt.Errorf("decrypting %s: %w", s.path, err)
+		}
+		if err := json.Unmarshal(plaintext, &data); err != nil {
+			return fmt.Errorf("parsing %s: %w", s.path, err)
+		}
+	}
+
+	if err := fn(&data); err != nil {
+		return err
+	}
+
+	plaintext, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", s.path, err)
+	}
+
+	ciphertext, err := encrypt(plaintext, s.passphrase)
+	if err != nil {
+		return fmt.Errorf("encrypting %s: %w", s.path, err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, ciphertext, 0600); err != nil {
+		return fmt.Errorf("writing %s: %w", tmp, err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// encrypt produces salt || nonce || ciphertext, deriving a fresh key from
+// passphrase and a random salt on every write so the on-disk key changes
+// even if the passphrase doesn't.
+func encrypt(plaintext []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, encryptedSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	out := make([]byte, 0, len(salt)+len(nonce)+len(ciphertext))
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	out = append(out, ciphertext...)
+	return out, nil
+}
+
+func decrypt(blob []byte, passphrase string) ([]byte, error) {
+	if len(blob) < encryptedSaltSize {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	salt, rest := blob[:encryptedSaltSize], blob[encryptedSaltSize:]
+
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := rest[:gcm.NonceSize()], rest[gcm.NonceSize():]
+
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *encryptedStore) Close() error { return nil }
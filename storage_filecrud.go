@@ -0,0 +1,131 @@
+package main
+
+// fileCRUD implements every Store method except Close in terms of a
+// withLock callback: load the current Storage, let fn mutate it, then
+// persist it back however the concrete backend wants (plain JSON for
+// jsonStore, AES-GCM ciphertext for encryptedStore). jsonStore and
+// encryptedStore each embed a fileCRUD and point withLock at their own
+// locked load/persist method, so the two backends share one copy of the
+// actual CRUD logic instead of maintaining near-identical duplicates.
+type fileCRUD struct {
+	withLock func(fn func(*Storage) error) error
+}
+
+func (s *fileCRUD) ListUsers() ([]User, error) {
+	var users []User
+	err := s.withLock(func(data *Storage) error {
+		users = data.Users
+		return nil
+	})
+	return users, err
+}
+
+func (s *fileCRUD) CreateUser(user User) (User, error) {
+	err := s.withLock(func(data *Storage) error {
+		user.ID = nextID(data.Users, func(u User) int { return u.ID })
+		data.Users = append(data.Users, user)
+		return nil
+	})
+	return user, err
+}
+
+func (s *fileCRUD) FindUserByEmail(email string) (User, bool, error) {
+	var (
+		found User
+		ok    bool
+	)
+	err := s.withLock(func(data *Storage) error {
+		for _, user := range data.Users {
+			if user.Email == email {
+				found, ok = user, true
+				return nil
+			}
+		}
+		return nil
+	})
+	return found, ok, err
+}
+
+func (s *fileCRUD) ListTasks(userID int) ([]Task, error) {
+	var tasks []Task
+	err := s.withLock(func(data *Storage) error {
+		for _, task := range data.Tasks {
+			if task.UserID == userID {
+				tasks = append(tasks, task)
+			}
+		}
+		return nil
+	})
+	return tasks, err
+}
+
+func (s *fileCRUD) CreateTask(task Task) (Task, error) {
+	err := s.withLock(func(data *Storage) error {
+		task.ID = nextID(data.Tasks, func(t Task) int { return t.ID })
+		data.Tasks = append(data.Tasks, task)
+		return nil
+	})
+	return task, err
+}
+
+func (s *fileCRUD) UpdateTask(task Task) error {
+	return s.withLock(func(data *Storage) error {
+		for i, existing := range data.Tasks {
+			if existing.ID == task.ID && existing.UserID == task.UserID {
+				data.Tasks[i] = task
+				return nil
+			}
+		}
+		return ErrNotFound
+	})
+}
+
+func (s *fileCRUD) DeleteTask(userID, taskID int) error {
+	return s.withLock(func(data *Storage) error {
+		for i, existing := range data.Tasks {
+			if existing.ID == taskID && existing.UserID == userID {
+				data.Tasks = append(data.Tasks[:i], data.Tasks[i+1:]...)
+				return nil
+			}
+		}
+		return ErrNotFound
+	})
+}
+
+func (s *fileCRUD) ListCategories(userID int) ([]Category, error) {
+	var categories []Category
+	err := s.withLock(func(data *Storage) error {
+		for _, category := range data.Categories {
+			if category.UserID == userID {
+				categories = append(categories, category)
+			}
+		}
+		return nil
+	})
+	return categories, err
+}
+
+func (s *fileCRUD) CreateCategory(category Category) (Category, error) {
+	err := s.withLock(func(data *Storage) error {
+		category.ID = nextID(data.Categories, func(c Category) int { return c.ID })
+		data.Categories = append(data.Categories, category)
+		return nil
+	})
+	return category, err
+}
+
+func (s *fileCRUD) Snapshot() (Storage, error) {
+	var snapshot Storage
+	err := s.withLock(func(data *Storage) error {
+		snapshot = *data
+		return nil
+	})
+	return snapshot, err
+}
+
+func (s *fileCRUD) Restore(data Storage) error {
+	return s.withLock(func(current *Storage) error {
+		*current = data
+		return nil
+	})
+}
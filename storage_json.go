@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// jsonStore is the original pretty-printed-JSON-file backend, now behind
+// the Store interface. All reads and writes go through lockedUpdate,
+// which takes both an in-process mutex (guards concurrent goroutines)
+// and an flock on the file itself (guards concurrent tasky processes).
+// Every CRUD method comes from the embedded fileCRUD.
+type jsonStore struct {
+	fileCRUD
+	path string
+	mu   sync.Mutex
+}
+
+func newJSONStore(path string) (Store, error) {
+	if path == "" {
+		path = dataFile
+	}
+	s := &jsonStore{path: path}
+	s.fileCRUD = fileCRUD{withLock: s.lockedUpdate}
+	return s, nil
+}
+
+func (s *jsonStore) lockedUpdate(fn func(*Storage) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", s.path, err)
+	}
+	defer file.Close()
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("locking %s: %w", s.path, err)
+	}
+	defer syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+
+	data := Storage{Users: []User{}, Tasks: []Task{}, Categories: []Category{}}
+
+	if info, statErr := file.Stat(); statErr == nil && info.Size() > 0 {
+		if err := json.NewDecoder(file).Decode(&data); err != nil {
+			return fmt.Errorf("parsing %s: %w", s.path, err)
+		}
+	}
+
+	if err := fn(&data); err != nil {
+		return err
+	}
+
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", s.path, err)
+	}
+
+	if err := file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := file.WriteAt(encoded, 0); err != nil {
+		return fmt.Errorf("writing %s: %w", s.path, err)
+	}
+
+	return nil
+}
+
+func (s *jsonStore) Close() error { return nil }
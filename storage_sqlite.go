@@ -0,0 +1,314 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS users (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	email TEXT NOT NULL UNIQUE,
+	password TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS categories (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	user_id INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS tasks (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	date TEXT NOT NULL,
+	status TEXT NOT NULL,
+	user_id INTEGER NOT NULL,
+	category_id INTEGER NOT NULL,
+	created_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL,
+	completed_at TEXT
+);
+`
+
+// sqliteStore persists the same data as jsonStore but in a SQLite database,
+// giving transactional writes instead of whole-file rewrites. Selected with
+// --store sqlite (the path is the .db file, defaulting to tasky.db).
+type sqliteStore struct {
+	db *sql.DB
+}
+
+func newSQLiteStore(path string) (Store, error) {
+	if path == "" {
+		path = "tasky.db"
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite store %s: %w", path, err)
+	}
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initializing sqlite schema: %w", err)
+	}
+
+	return &sqliteStore{db: db}, nil
+}
+
+func (s *sqliteStore) ListUsers() ([]User, error) {
+	rows, err := s.db.Query(`SELECT id, name, email, password FROM users`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		var u User
+		if err := rows.Scan(&u.ID, &u.Name, &u.Email, &u.Password); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+func (s *sqliteStore) CreateUser(user User) (User, error) {
+	res, err := s.db.Exec(`INSERT INTO users (name, email, password) VALUES (?, ?, ?)`,
+		user.Name, user.Email, user.Password)
+	if err != nil {
+		return User{}, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return User{}, err
+	}
+	user.ID = int(id)
+	return user, nil
+}
+
+func (s *sqliteStore) FindUserByEmail(email string) (User, bool, error) {
+	var u User
+	err := s.db.QueryRow(`SELECT id, name, email, password FROM users WHERE email = ?`, email).
+		Scan(&u.ID, &u.Name, &u.Email, &u.Password)
+	if err == sql.ErrNoRows {
+		return User{}, false, nil
+	}
+	if err != nil {
+		return User{}, false, err
+	}
+	return u, true, nil
+}
+
+func (s *sqliteStore) ListTasks(userID int) ([]Task, error) {
+	rows, err := s.db.Query(
+		`SELECT id, name, date, status, user_id, category_id, created_at, updated_at, completed_at
+		 FROM tasks WHERE user_id = ?`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+func scanTask(rows *sql.Rows) (Task, error) {
+	var (
+		t             Task
+		dateStr       string
+		statusRaw     string
+		createdAtStr  string
+		updatedAtStr  string
+		completedAtNS sql.NullString
+	)
+	if err := rows.Scan(&t.ID, &t.Name, &dateStr, &statusRaw, &t.UserID, &t.CategoryID,
+		&createdAtStr, &updatedAtStr, &completedAtNS); err != nil {
+		return Task{}, err
+	}
+
+	date, err := time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		return Task{}, fmt.Errorf("parsing stored task date %q: %w", dateStr, err)
+	}
+	t.Date = date
+	t.Status = TaskStatus(statusRaw)
+
+	if t.CreatedAt, err = time.Parse(time.RFC3339, createdAtStr); err != nil {
+		return Task{}, fmt.Errorf("parsing stored created_at %q: %w", createdAtStr, err)
+	}
+	if t.UpdatedAt, err = time.Parse(time.RFC3339, updatedAtStr); err != nil {
+		return Task{}, fmt.Errorf("parsing stored updated_at %q: %w", updatedAtStr, err)
+	}
+	if completedAtNS.Valid {
+		completedAt, err := time.Parse(time.RFC3339, completedAtNS.String)
+		if err != nil {
+			return Task{}, fmt.Errorf("parsing stored completed_at %q: %w", completedAtNS.String, err)
+		}
+		t.CompletedAt = &completedAt
+	}
+
+	return t, nil
+}
+
+func (s *sqliteStore) CreateTask(task Task) (Task, error) {
+	res, err := s.db.Exec(
+		`INSERT INTO tasks (name, date, status, user_id, category_id, created_at, updated_at, completed_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		task.Name, task.Date.Format(time.RFC3339), task.Status, task.UserID, task.CategoryID,
+		task.CreatedAt.Format(time.RFC3339), task.UpdatedAt.Format(time.RFC3339), formatCompletedAt(task.CompletedAt))
+	if err != nil {
+		return Task{}, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return Task{}, err
+	}
+	task.ID = int(id)
+	return task, nil
+}
+
+func (s *sqliteStore) UpdateTask(task Task) error {
+	res, err := s.db.Exec(
+		`UPDATE tasks SET name = ?, date = ?, status = ?, category_id = ?, updated_at = ?, completed_at = ?
+		 WHERE id = ? AND user_id = ?`,
+		task.Name, task.Date.Format(time.RFC3339), task.Status, task.CategoryID,
+		task.UpdatedAt.Format(time.RFC3339), formatCompletedAt(task.CompletedAt), task.ID, task.UserID)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(res)
+}
+
+func formatCompletedAt(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (s *sqliteStore) DeleteTask(userID, taskID int) error {
+	res, err := s.db.Exec(`DELETE FROM tasks WHERE id = ? AND user_id = ?`, taskID, userID)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(res)
+}
+
+func checkRowsAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *sqliteStore) ListCategories(userID int) ([]Category, error) {
+	rows, err := s.db.Query(`SELECT id, name, user_id FROM categories WHERE user_id = ?`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []Category
+	for rows.Next() {
+		var c Category
+		if err := rows.Scan(&c.ID, &c.Name, &c.UserID); err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+	return categories, rows.Err()
+}
+
+func (s *sqliteStore) CreateCategory(category Category) (Category, error) {
+	res, err := s.db.Exec(`INSERT INTO categories (name, user_id) VALUES (?, ?)`, category.Name, category.UserID)
+	if err != nil {
+		return Category{}, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return Category{}, err
+	}
+	category.ID = int(id)
+	return category, nil
+}
+
+func (s *sqliteStore) Snapshot() (Storage, error) {
+	users, err := s.ListUsers()
+	if err != nil {
+		return Storage{}, err
+	}
+
+	var allTasks []Task
+	var allCategories []Category
+	for _, user := range users {
+		tasks, err := s.ListTasks(user.ID)
+		if err != nil {
+			return Storage{}, err
+		}
+		categories, err := s.ListCategories(user.ID)
+		if err != nil {
+			return Storage{}, err
+		}
+		allTasks = append(allTasks, tasks...)
+		allCategories = append(allCategories, categories...)
+	}
+
+	return Storage{Users: users, Tasks: allTasks, Categories: allCategories}, nil
+}
+
+func (s *sqliteStore) Restore(data Storage) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"users", "categories", "tasks"} {
+		if _, err := tx.Exec(fmt.Sprintf("DELETE FROM %s", table)); err != nil {
+			return err
+		}
+	}
+
+	for _, u := range data.Users {
+		if _, err := tx.Exec(`INSERT INTO users (id, name, email, password) VALUES (?, ?, ?, ?)`,
+			u.ID, u.Name, u.Email, u.Password); err != nil {
+			return err
+		}
+	}
+	for _, c := range data.Categories {
+		if _, err := tx.Exec(`INSERT INTO categories (id, name, user_id) VALUES (?, ?, ?)`,
+			c.ID, c.Name, c.UserID); err != nil {
+			return err
+		}
+	}
+	for _, t := range data.Tasks {
+		if _, err := tx.Exec(
+			`INSERT INTO tasks (id, name, date, status, user_id, category_id, created_at, updated_at, completed_at)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			t.ID, t.Name, t.Date.Format(time.RFC3339), t.Status, t.UserID, t.CategoryID,
+			t.CreatedAt.Format(time.RFC3339), t.UpdatedAt.Format(time.RFC3339), formatCompletedAt(t.CompletedAt)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// newTestStore opens a fresh store of kind at a temp path, unlocking it
+// with a passphrase when kind is "encrypted". Every backend is expected
+// to behave identically through the Store interface.
+func newTestStore(t *testing.T, kind string) Store {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "data")
+	passphrase := ""
+	if kind == "encrypted" {
+		passphrase = "test-passphrase"
+	}
+
+	store, err := openStore(kind, path, passphrase)
+	if err != nil {
+		t.Fatalf("openStore(%q): %v", kind, err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestStoreCRUD(t *testing.T) {
+	for _, kind := range []string{"json", "encrypted", "sqlite"} {
+		t.Run(kind, func(t *testing.T) {
+			store := newTestStore(t, kind)
+
+			alice, err := store.CreateUser(User{Name: "alice", Email: "alice@x.com", Password: "hash1"})
+			if err != nil {
+				t.Fatalf("CreateUser(alice): %v", err)
+			}
+			bob, err := store.CreateUser(User{Name: "bob", Email: "bob@x.com", Password: "hash2"})
+			if err != nil {
+				t.Fatalf("CreateUser(bob): %v", err)
+			}
+			if alice.ID == bob.ID {
+				t.Fatalf("expected distinct user IDs, got %d and %d", alice.ID, bob.ID)
+			}
+
+			found, ok, err := store.FindUserByEmail("bob@x.com")
+			if err != nil || !ok || found.ID != bob.ID {
+				t.Fatalf("FindUserByEmail(bob): got %+v, %v, %v", found, ok, err)
+			}
+			if _, ok, err := store.FindUserByEmail("nobody@x.com"); err != nil || ok {
+				t.Fatalf("FindUserByEmail(nobody): got ok=%v, err=%v", ok, err)
+			}
+
+			category, err := store.CreateCategory(Category{Name: "work", UserID: alice.ID})
+			if err != nil {
+				t.Fatalf("CreateCategory: %v", err)
+			}
+
+			task, err := store.CreateTask(Task{Name: "write report", UserID: alice.ID, CategoryID: category.ID, Status: TaskStatusPending})
+			if err != nil {
+				t.Fatalf("CreateTask: %v", err)
+			}
+
+			task.Status = TaskStatusCompleted
+			if err := store.UpdateTask(task); err != nil {
+				t.Fatalf("UpdateTask: %v", err)
+			}
+
+			tasks, err := store.ListTasks(alice.ID)
+			if err != nil {
+				t.Fatalf("ListTasks: %v", err)
+			}
+			if len(tasks) != 1 || tasks[0].Status != TaskStatusCompleted {
+				t.Fatalf("expected one completed task, got %+v", tasks)
+			}
+
+			if tasks, err := store.ListTasks(bob.ID); err != nil || len(tasks) != 0 {
+				t.Fatalf("expected bob to have no tasks, got %+v, %v", tasks, err)
+			}
+
+			if err := store.DeleteTask(alice.ID, task.ID); err != nil {
+				t.Fatalf("DeleteTask: %v", err)
+			}
+			if err := store.DeleteTask(alice.ID, task.ID); err != ErrNotFound {
+				t.Fatalf("DeleteTask twice: want ErrNotFound, got %v", err)
+			}
+
+			snapshot, err := store.Snapshot()
+			if err != nil {
+				t.Fatalf("Snapshot: %v", err)
+			}
+			if len(snapshot.Users) != 2 || len(snapshot.Categories) != 1 || len(snapshot.Tasks) != 0 {
+				t.Fatalf("unexpected snapshot: %+v", snapshot)
+			}
+		})
+	}
+}
+
+// TestEncryptedStoreRequiresPassphrase checks that a store opened without
+// SetPassphrase refuses to read or write rather than silently using an
+// empty key.
+func TestEncryptedStoreRequiresPassphrase(t *testing.T) {
+	store, err := openStore("encrypted", filepath.Join(t.TempDir(), "data.enc"), "")
+	if err != nil {
+		t.Fatalf("openStore: %v", err)
+	}
+	defer store.Close()
+
+	if _, err := store.ListUsers(); err == nil {
+		t.Fatal("expected ListUsers on a locked store to fail")
+	}
+}
+
+// TestEncryptedStoreMultiUser guards against keying the store off a
+// single user's login password: two users registering in sequence, both
+// against the same store passphrase, must both persist correctly.
+func TestEncryptedStoreMultiUser(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.enc")
+
+	first, err := openStore("encrypted", path, "store-secret")
+	if err != nil {
+		t.Fatalf("openStore (first): %v", err)
+	}
+	if _, err := first.CreateUser(User{Name: "alice", Email: "alice@x.com", Password: "hash1"}); err != nil {
+		t.Fatalf("CreateUser(alice): %v", err)
+	}
+	first.Close()
+
+	second, err := openStore("encrypted", path, "store-secret")
+	if err != nil {
+		t.Fatalf("openStore (second): %v", err)
+	}
+	defer second.Close()
+	if _, err := second.CreateUser(User{Name: "bob", Email: "bob@x.com", Password: "hash2"}); err != nil {
+		t.Fatalf("CreateUser(bob): %v", err)
+	}
+
+	users, err := second.ListUsers()
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("expected both users to survive, got %+v", users)
+	}
+}
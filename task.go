@@ -0,0 +1,383 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// dueSoonWindow is how far into the future a pending or inProgress task counts as
+// "due soon" for `task list --due-soon`.
+const dueSoonWindow = 72 * time.Hour
+
+var taskCmd = &cobra.Command{
+	Use:   "task",
+	Short: "Manage tasks",
+	// A child's PersistentPreRunE replaces the parent's rather than
+	// chaining to it, so rootCmd's store/logger setup has to be called
+	// explicitly here before requireLogin() can use either.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := rootCmd.PersistentPreRunE(cmd, args); err != nil {
+			return err
+		}
+		return requireLogin()
+	},
+}
+
+var taskCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a task",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		date, _ := cmd.Flags().GetString("date")
+		category, _ := cmd.Flags().GetString("category")
+
+		task, err := addTask(name, date, category)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Task created: #%d %s\n", task.ID, task.Name)
+		return nil
+	},
+}
+
+var (
+	taskUpdateID       int
+	taskUpdateName     string
+	taskUpdateDate     string
+	taskUpdateCategory string
+)
+
+var taskUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a task's name, date or category",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		task, err := updateTask(taskUpdateID, cmd.Flags(), taskUpdateName, taskUpdateDate, taskUpdateCategory)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Task updated: #%d %s\n", task.ID, task.Name)
+		return nil
+	},
+}
+
+var taskDeleteID int
+
+var taskDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a task",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := store.DeleteTask(loggedInUser.ID, taskDeleteID); err != nil {
+			return fmt.Errorf("deleting task #%d: %w", taskDeleteID, err)
+		}
+		fmt.Printf("Task deleted: #%d\n", taskDeleteID)
+		return nil
+	},
+}
+
+var (
+	taskStatusID     int
+	taskStatusTarget string
+)
+
+var taskSetStatusCmd = &cobra.Command{
+	Use:   "set-status",
+	Short: "Move a task to pending, inProgress or completed",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		task, err := setTaskStatus(taskStatusID, TaskStatus(taskStatusTarget))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Task #%d is now %s\n", task.ID, task.Status)
+		return nil
+	},
+}
+
+var (
+	taskListStatus   string
+	taskListCategory string
+	taskListFrom     string
+	taskListTo       string
+	taskListDueSoon  bool
+	taskListOverdue  bool
+	taskListOutput   string
+)
+
+var taskListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tasks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tasks, err := filterTasks(taskFilters{
+			status:   taskListStatus,
+			category: taskListCategory,
+			from:     taskListFrom,
+			to:       taskListTo,
+			dueSoon:  taskListDueSoon,
+			overdue:  taskListOverdue,
+		})
+		if err != nil {
+			return err
+		}
+		return renderTasks(tasks, taskListOutput)
+	},
+}
+
+func init() {
+	taskCreateCmd.Flags().String("name", "", "task name (required)")
+	taskCreateCmd.Flags().String("date", "", "task due date, YYYY-MM-DD or RFC3339 (required)")
+	taskCreateCmd.Flags().String("category", "", "category name (required)")
+	taskCreateCmd.MarkFlagRequired("name")
+	taskCreateCmd.MarkFlagRequired("date")
+	taskCreateCmd.MarkFlagRequired("category")
+
+	taskUpdateCmd.Flags().IntVar(&taskUpdateID, "id", 0, "ID of the task to update (required)")
+	taskUpdateCmd.Flags().StringVar(&taskUpdateName, "name", "", "new task name")
+	taskUpdateCmd.Flags().StringVar(&taskUpdateDate, "date", "", "new task due date, YYYY-MM-DD or RFC3339")
+	taskUpdateCmd.Flags().StringVar(&taskUpdateCategory, "category", "", "new category name")
+	taskUpdateCmd.MarkFlagRequired("id")
+
+	taskDeleteCmd.Flags().IntVar(&taskDeleteID, "id", 0, "ID of the task to delete (required)")
+	taskDeleteCmd.MarkFlagRequired("id")
+
+	taskSetStatusCmd.Flags().IntVar(&taskStatusID, "id", 0, "ID of the task to transition (required)")
+	taskSetStatusCmd.Flags().StringVar(&taskStatusTarget, "status", "", "target status: pending, inProgress or completed (required)")
+	taskSetStatusCmd.MarkFlagRequired("id")
+	taskSetStatusCmd.MarkFlagRequired("status")
+
+	taskListCmd.Flags().StringVar(&taskListStatus, "status", "", "filter by status: pending, inProgress or completed")
+	taskListCmd.Flags().StringVar(&taskListCategory, "category", "", "filter by category name")
+	taskListCmd.Flags().StringVar(&taskListFrom, "from", "", "only show tasks due on or after this date (YYYY-MM-DD)")
+	taskListCmd.Flags().StringVar(&taskListTo, "to", "", "only show tasks due on or before this date (YYYY-MM-DD)")
+	taskListCmd.Flags().BoolVar(&taskListDueSoon, "due-soon", false, "only show pending/inProgress tasks due within 72 hours")
+	taskListCmd.Flags().BoolVar(&taskListOverdue, "overdue", false, "only show pending/inProgress tasks whose due date has passed")
+	taskListCmd.Flags().StringVar(&taskListOutput, "output", "table", "output format: table, json or csv")
+
+	taskCmd.AddCommand(taskCreateCmd, taskUpdateCmd, taskDeleteCmd, taskSetStatusCmd, taskListCmd)
+}
+
+// addTask resolves categoryName to the logged-in user's category and
+// creates the task. It's shared by the `task create` command and the
+// interactive `tasky shell` prompt.
+func addTask(name, dateStr, categoryName string) (Task, error) {
+	parsedDate, err := parseTaskDate(dateStr)
+	if err != nil {
+		return Task{}, err
+	}
+
+	category, err := findUserCategoryByName(categoryName)
+	if err != nil {
+		return Task{}, err
+	}
+
+	now := time.Now()
+	task := Task{
+		Name:       name,
+		Date:       parsedDate,
+		CategoryID: category.ID,
+		Status:     TaskStatusPending,
+		UserID:     loggedInUser.ID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	return store.CreateTask(task)
+}
+
+// updateTask applies whichever of name/date/category were actually
+// passed on the command line, leaving the rest of the task untouched.
+func updateTask(id int, flags *pflag.FlagSet, name, date, categoryName string) (Task, error) {
+	task, err := findUserTask(id)
+	if err != nil {
+		return Task{}, err
+	}
+
+	if flags.Changed("name") {
+		task.Name = name
+	}
+	if flags.Changed("date") {
+		parsedDate, err := parseTaskDate(date)
+		if err != nil {
+			return Task{}, err
+		}
+		task.Date = parsedDate
+	}
+	if flags.Changed("category") {
+		category, err := findUserCategoryByName(categoryName)
+		if err != nil {
+			return Task{}, err
+		}
+		task.CategoryID = category.ID
+	}
+
+	task.UpdatedAt = time.Now()
+	if err := store.UpdateTask(task); err != nil {
+		return Task{}, fmt.Errorf("updating task #%d: %w", id, err)
+	}
+	return task, nil
+}
+
+// setTaskStatus validates target against the three TaskStatus constants
+// and records CompletedAt when (and only when) the task becomes completed.
+func setTaskStatus(id int, target TaskStatus) (Task, error) {
+	if !validTaskStatus(target) {
+		return Task{}, fmt.Errorf("invalid status %q (want pending, inProgress or completed)", target)
+	}
+
+	task, err := findUserTask(id)
+	if err != nil {
+		return Task{}, err
+	}
+
+	task.Status = target
+	task.UpdatedAt = time.Now()
+	if target == TaskStatusCompleted {
+		now := time.Now()
+		task.CompletedAt = &now
+	} else {
+		task.CompletedAt = nil
+	}
+
+	if err := store.UpdateTask(task); err != nil {
+		return Task{}, fmt.Errorf("updating task #%d: %w", id, err)
+	}
+	return task, nil
+}
+
+func findUserTask(id int) (Task, error) {
+	tasks, err := store.ListTasks(loggedInUser.ID)
+	if err != nil {
+		return Task{}, err
+	}
+	for _, task := range tasks {
+		if task.ID == id {
+			return task, nil
+		}
+	}
+	return Task{}, fmt.Errorf("no task #%d for the current user", id)
+}
+
+func parseTaskDate(date string) (time.Time, error) {
+	if parsed, err := time.Parse("2006-01-02", date); err == nil {
+		return parsed, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, date); err == nil {
+		return parsed, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD (e.g., 2025-12-02) or RFC3339 (e.g., 2025-12-02T10:00:00Z)", date)
+}
+
+func findUserCategoryByName(name string) (Category, error) {
+	categories, err := store.ListCategories(loggedInUser.ID)
+	if err != nil {
+		return Category{}, err
+	}
+	for _, category := range categories {
+		if category.Name == name {
+			return category, nil
+		}
+	}
+	return Category{}, fmt.Errorf("no category named %q; create it first with `tasky category create`", name)
+}
+
+// taskFilters holds the optional `task list` filters; zero values mean
+// "don't filter on this field".
+type taskFilters struct {
+	status   string
+	category string
+	from     string
+	to       string
+	dueSoon  bool
+	overdue  bool
+}
+
+// filterTasks lists the logged-in user's tasks, applying the optional
+// filters in-process (the Store only filters by user, since none of the
+// current backends index on these fields).
+func filterTasks(f taskFilters) ([]Task, error) {
+	tasks, err := store.ListTasks(loggedInUser.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var categoryID int
+	if f.category != "" {
+		category, err := findUserCategoryByName(f.category)
+		if err != nil {
+			return nil, err
+		}
+		categoryID = category.ID
+	}
+
+	var fromDate, toDate time.Time
+	if f.from != "" {
+		if fromDate, err = parseTaskDate(f.from); err != nil {
+			return nil, err
+		}
+	}
+	if f.to != "" {
+		if toDate, err = parseTaskDate(f.to); err != nil {
+			return nil, err
+		}
+		// A bare YYYY-MM-DD covers that whole day, not just its midnight.
+		if len(f.to) == len("2006-01-02") {
+			toDate = toDate.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+
+	now := time.Now()
+
+	var filtered []Task
+	for _, task := range tasks {
+		if f.status != "" && string(task.Status) != f.status {
+			continue
+		}
+		if f.category != "" && task.CategoryID != categoryID {
+			continue
+		}
+		if !fromDate.IsZero() && task.Date.Before(fromDate) {
+			continue
+		}
+		if !toDate.IsZero() && task.Date.After(toDate) {
+			continue
+		}
+		if f.dueSoon && (task.Status == TaskStatusCompleted || task.Date.After(now.Add(dueSoonWindow)) || task.Date.Before(now)) {
+			continue
+		}
+		if f.overdue && (task.Status == TaskStatusCompleted || !task.Date.Before(now)) {
+			continue
+		}
+		filtered = append(filtered, task)
+	}
+	return filtered, nil
+}
+
+// createTask is the interactive counterpart used by `tasky shell`.
+func createTask() {
+	name := scanInput("Enter your task name: ")
+	date := scanInput("Enter your task date: ")
+
+	for _, category := range getUserCategories() {
+		fmt.Printf("%d. %s\n", category.ID, category.Name)
+	}
+	categoryName := scanInput("Enter your task category name: ")
+
+	if _, err := addTask(name, date, categoryName); err != nil {
+		logger.Error("creating task", "name", name, "err", err)
+	}
+}
+
+// listTasks is the interactive counterpart used by `tasky shell`.
+func listTasks() {
+	tasks, err := store.ListTasks(loggedInUser.ID)
+	if err != nil {
+		logger.Error("listing tasks", "user_id", loggedInUser.ID, "err", err)
+		return
+	}
+	if err := renderTasks(tasks, "table"); err != nil {
+		logger.Error("printing tasks", "err", err)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// withTestSession points the package-level store/loggedInUser at a fresh
+// json store for the duration of the test, restoring the previous values
+// after. task.go's helpers all read those globals directly, the same way
+// a real command invocation would after requireLogin().
+func withTestSession(t *testing.T) User {
+	t.Helper()
+
+	prevStore, prevUser := store, loggedInUser
+	t.Cleanup(func() {
+		if store != nil {
+			store.Close()
+		}
+		store, loggedInUser = prevStore, prevUser
+	})
+
+	s, err := openStore("json", filepath.Join(t.TempDir(), "data.json"), "")
+	if err != nil {
+		t.Fatalf("openStore: %v", err)
+	}
+	store = s
+
+	user, err := store.CreateUser(User{Name: "alice", Email: "alice@x.com", Password: "hash"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	loggedInUser = user
+	return user
+}
+
+func TestValidTaskStatus(t *testing.T) {
+	for _, s := range []TaskStatus{TaskStatusPending, TaskStatusInProgress, TaskStatusCompleted} {
+		if !validTaskStatus(s) {
+			t.Errorf("validTaskStatus(%q) = false, want true", s)
+		}
+	}
+	if validTaskStatus(TaskStatus("bogus")) {
+		t.Error(`validTaskStatus("bogus") = true, want false`)
+	}
+}
+
+func TestSetTaskStatus(t *testing.T) {
+	withTestSession(t)
+
+	category, err := store.CreateCategory(Category{Name: "work", UserID: loggedInUser.ID})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	task, err := addTask("write report", "2026-08-01", category.Name)
+	if err != nil {
+		t.Fatalf("addTask: %v", err)
+	}
+
+	if _, err := setTaskStatus(task.ID, TaskStatus("bogus")); err == nil {
+		t.Fatal("setTaskStatus with an invalid status should have failed")
+	}
+
+	updated, err := setTaskStatus(task.ID, TaskStatusCompleted)
+	if err != nil {
+		t.Fatalf("setTaskStatus(completed): %v", err)
+	}
+	if updated.Status != TaskStatusCompleted || updated.CompletedAt == nil {
+		t.Fatalf("expected a completed task with CompletedAt set, got %+v", updated)
+	}
+
+	// Moving back off completed must clear CompletedAt rather than leave
+	// a stale timestamp on a task that is no longer completed.
+	reopened, err := setTaskStatus(task.ID, TaskStatusInProgress)
+	if err != nil {
+		t.Fatalf("setTaskStatus(inProgress): %v", err)
+	}
+	if reopened.CompletedAt != nil {
+		t.Fatalf("expected CompletedAt to be cleared, got %v", reopened.CompletedAt)
+	}
+}